@@ -0,0 +1,98 @@
+package main
+
+// Memory is implemented by anything that can be mapped onto the bus: RAM,
+// ROM, PPU/APU registers, or a cartridge mapper. Addresses are passed through
+// unmodified, so a module is responsible for mirroring its own address space
+// (e.g. 2KB internal RAM mirrored every 0x800 bytes).
+type Memory interface {
+	Read(addr uint16) uint8
+	Write(addr uint16, val uint8)
+}
+
+// Bus is the CPU's view of the NES memory map. Modules are registered
+// against an address range with Attach, and reads/writes are routed to
+// whichever module claims that range.
+//
+// https://wiki.nesdev.com/w/index.php/CPU_memory_map
+type Bus interface {
+	Memory
+	Attach(module Memory, name string, start, end uint16)
+	Read16(addr uint16) uint16
+}
+
+// mapping associates a Memory module with the address range it owns.
+type mapping struct {
+	module Memory
+	name   string
+	start  uint16
+	end    uint16
+}
+
+// MemoryBus is the default Bus implementation: a list of mapped modules
+// searched in attach order.
+type MemoryBus struct {
+	mappings []mapping
+}
+
+// NewMemoryBus returns an empty bus with no modules attached.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{}
+}
+
+// Attach registers module to handle addresses in [start, end].
+func (b *MemoryBus) Attach(module Memory, name string, start, end uint16) {
+	b.mappings = append(b.mappings, mapping{module, name, start, end})
+}
+
+// find returns the mapping covering addr, or nil if nothing is attached
+// there.
+func (b *MemoryBus) find(addr uint16) *mapping {
+	for i := range b.mappings {
+		m := &b.mappings[i]
+		if addr >= m.start && addr <= m.end {
+			return m
+		}
+	}
+	return nil
+}
+
+// Read returns the byte at addr, or 0 if no module is mapped there.
+func (b *MemoryBus) Read(addr uint16) uint8 {
+	if m := b.find(addr); m != nil {
+		return m.module.Read(addr)
+	}
+	return 0
+}
+
+// Write stores val at addr. Writes to unmapped addresses are ignored.
+func (b *MemoryBus) Write(addr uint16, val uint8) {
+	if m := b.find(addr); m != nil {
+		m.module.Write(addr, val)
+	}
+}
+
+// Read16 reads a little-endian 16-bit value starting at addr.
+func (b *MemoryBus) Read16(addr uint16) uint16 {
+	lo := b.Read(addr)
+	hi := b.Read(addr + 1)
+	return uint16(hi)<<8 | uint16(lo)
+}
+
+// Ram is the NES's 2KB of internal work RAM at $0000-$07FF, mirrored three
+// times through $1FFF.
+type Ram struct {
+	data [0x800]uint8
+}
+
+// NewRam returns a zeroed 2KB RAM module.
+func NewRam() *Ram {
+	return &Ram{}
+}
+
+func (r *Ram) Read(addr uint16) uint8 {
+	return r.data[addr%0x800]
+}
+
+func (r *Ram) Write(addr uint16, val uint8) {
+	r.data[addr%0x800] = val
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMemoryBusRamMirroring(t *testing.T) {
+	bus := NewMemoryBus()
+	bus.Attach(NewRam(), "RAM", 0x0000, 0x1fff)
+
+	// write through the base mapping, read back through each mirror
+	bus.Write(0x0000, 0x42)
+	if bus.Read(0x0800) != 0x42 || bus.Read(0x1000) != 0x42 || bus.Read(0x1800) != 0x42 {
+		t.Fail()
+	}
+}
+
+func TestMemoryBusUnmapped(t *testing.T) {
+	bus := NewMemoryBus()
+	if bus.Read(0x4000) != 0 {
+		t.Fail()
+	}
+	// should not panic
+	bus.Write(0x4000, 0xff)
+}
+
+func TestMemoryBusRead16(t *testing.T) {
+	bus := NewMemoryBus()
+	bus.Attach(NewRam(), "RAM", 0x0000, 0x1fff)
+
+	bus.Write(0x00, 0xcd)
+	bus.Write(0x01, 0xab)
+	if bus.Read16(0x00) != 0xabcd {
+		t.Fail()
+	}
+}
@@ -1,10 +1,5 @@
 package main
 
-import (
-	"fmt"
-	"math/bits"
-)
-
 type Flags struct {
 	N bool // Sign (negative) flag
 	V bool // Overflow flag
@@ -15,14 +10,85 @@ type Flags struct {
 	C bool // Carry flag
 }
 
+// ToByte packs the flags into the status byte pushed by PHP/BRK, with the
+// unused bit (5) always set.
+func (f Flags) ToByte() uint8 {
+	b := uint8(0x20)
+	if f.N {
+		b |= 0x80
+	}
+	if f.V {
+		b |= 0x40
+	}
+	if f.B {
+		b |= 0x10
+	}
+	if f.D {
+		b |= 0x08
+	}
+	if f.I {
+		b |= 0x04
+	}
+	if f.Z {
+		b |= 0x02
+	}
+	if f.C {
+		b |= 0x01
+	}
+	return b
+}
+
+// FlagsFromByte unpacks a status byte as pulled by PLP/RTI.
+func FlagsFromByte(b uint8) Flags {
+	return Flags{
+		N: b&0x80 != 0,
+		V: b&0x40 != 0,
+		B: b&0x10 != 0,
+		D: b&0x08 != 0,
+		I: b&0x04 != 0,
+		Z: b&0x02 != 0,
+		C: b&0x01 != 0,
+	}
+}
+
 type Cpu struct {
-	A      uint8         // A Register
-	X      uint8         // X Register
-	Y      uint8         // Y Register
-	SP     uint8         // Stack Pointer
-	PC     uint16        // Program Counter
-	Flags  Flags         // CPU Flags
-	Memory [0xffff]uint8 // Memory space
+	A       uint8    // A Register
+	X       uint8    // X Register
+	Y       uint8    // Y Register
+	SP      uint8    // Stack Pointer
+	PC      uint16   // Program Counter
+	Flags   Flags    // CPU Flags
+	Cycles  uint64   // running cycle counter
+	Bus     Bus      // Memory bus (RAM, PPU/APU registers, cartridge)
+	Variant *Variant // CPU variant (NMOS 6502, Ricoh 2A03, CMOS 65C02)
+	Tracer  Tracer   // optional structured trace sink, see trace.go
+
+	// NMIPending and IRQPending latch a pending interrupt for Step to
+	// service between instructions. Set these from PPU vblank / APU frame
+	// IRQ logic; Step clears the latch once the interrupt is taken.
+	NMIPending bool
+	IRQPending bool
+}
+
+// push stores val at $0100+SP and decrements SP.
+func (c *Cpu) push(val uint8) {
+	c.Bus.Write(0x0100+uint16(c.SP), val)
+	c.SP--
+}
+
+// pull increments SP and returns the byte at $0100+SP.
+func (c *Cpu) pull() uint8 {
+	c.SP++
+	return c.Bus.Read(0x0100 + uint16(c.SP))
+}
+
+// read16zp reads a little-endian 16-bit value from two zero-page bytes
+// starting at zp, wrapping within the zero page rather than crossing into
+// page 1 - this is how IZX/IZY/ZPI fetch their pointer.
+func (c *Cpu) read16zp(zp uint8) uint16 {
+	lo := c.Bus.Read(uint16(zp))
+	hi := c.Bus.Read(uint16(zp + 1))
+	return uint16(hi)<<8 | uint16(lo)
 }
 
 // https://wiki.nesdev.com/w/index.php/CPU_addressing_modes
@@ -42,223 +108,721 @@ const (
 	MODE_IND                       // indirect
 	MODE_REL                       // relative
 	MODE_ACC                       // accumulator
+	MODE_ZPI                       // zero page indirect, 65C02 only: ($zp)
 )
 
-// getValueByMode returns the value to be used based on addressing mode plus a
-// formatting string
-func (c *Cpu) getValueByMode(mode AddressingMode) (uint8, string) {
+// Operand is the decoded result of an addressing mode: the effective
+// address (where applicable), the fetched value, and whether computing the
+// address crossed a page boundary.
+type Operand struct {
+	Mode        AddressingMode
+	Addr        uint16
+	Value       uint8
+	PageCrossed bool
+}
+
+// getOperand decodes the operand of the instruction at PC according to mode.
+func (c *Cpu) getOperand(mode AddressingMode) Operand {
 
 	switch mode {
 	case MODE_IMP:
-		return 0, ""
+		return Operand{Mode: mode}
 	case MODE_IMM:
-		value := c.Memory[c.PC+1]
-		return value, "#$%02x"
+		value := c.Bus.Read(c.PC + 1)
+		return Operand{Mode: mode, Value: value}
 	case MODE_ZEP:
-		index := c.Memory[c.PC+1]
-		value := c.Memory[index]
-		return value, "$%02x"
+		addr := uint16(c.Bus.Read(c.PC + 1))
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_ZPX:
-		index := (c.Memory[c.PC+1] + c.X)
-		value := c.Memory[index]
-		return value, "%02x,X"
+		addr := uint16(c.Bus.Read(c.PC+1) + c.X)
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_ZPY:
-		index := (c.Memory[c.PC+1] + c.Y)
-		value := c.Memory[index]
-		return value, "%02x,Y"
+		addr := uint16(c.Bus.Read(c.PC+1) + c.Y)
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_IZX:
-		return 0, "(%02x,X)" // FIXME
+		zp := c.Bus.Read(c.PC+1) + c.X
+		addr := c.read16zp(zp)
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_IZY:
-		return 0, "(%02x,Y)" // FIXME
+		zp := c.Bus.Read(c.PC + 1)
+		base := c.read16zp(zp)
+		addr := base + uint16(c.Y)
+		return Operand{
+			Mode:        mode,
+			Addr:        addr,
+			Value:       c.Bus.Read(addr),
+			PageCrossed: base&0xff00 != addr&0xff00,
+		}
+	case MODE_ZPI:
+		zp := c.Bus.Read(c.PC + 1)
+		addr := c.read16zp(zp)
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_ABS:
-		return 0, "a" // FIXME
+		addr := c.Bus.Read16(c.PC + 1)
+		return Operand{Mode: mode, Addr: addr, Value: c.Bus.Read(addr)}
 	case MODE_ABX:
-		return 0, "a,x" // FIXME
+		base := c.Bus.Read16(c.PC + 1)
+		addr := base + uint16(c.X)
+		return Operand{
+			Mode:        mode,
+			Addr:        addr,
+			Value:       c.Bus.Read(addr),
+			PageCrossed: base&0xff00 != addr&0xff00,
+		}
 	case MODE_ABY:
-		return 0, "a,y" // FIXME
+		base := c.Bus.Read16(c.PC + 1)
+		addr := base + uint16(c.Y)
+		return Operand{
+			Mode:        mode,
+			Addr:        addr,
+			Value:       c.Bus.Read(addr),
+			PageCrossed: base&0xff00 != addr&0xff00,
+		}
 	case MODE_IND:
-		return 0, "(%04x)" // FIXME
+		ptr := c.Bus.Read16(c.PC + 1)
+		var addr uint16
+		if c.Variant.JMPIndirectBug && uint8(ptr) == 0xff {
+			// NMOS bug: the high byte wraps to the start of the same page
+			// instead of crossing into the next one.
+			lo := c.Bus.Read(ptr)
+			hi := c.Bus.Read(ptr & 0xff00)
+			addr = uint16(hi)<<8 | uint16(lo)
+		} else {
+			addr = c.Bus.Read16(ptr)
+		}
+		return Operand{Mode: mode, Addr: addr}
 	case MODE_REL:
-		value := c.Memory[c.PC+1]
-		return value, "%02x"
+		offset := c.Bus.Read(c.PC + 1)
+		target := uint16(int32(c.PC) + 2 + int32(int8(offset)))
+		return Operand{Mode: mode, Addr: target, Value: offset}
 	case MODE_ACC:
-		return 0, "A" // FIXME
+		return Operand{Mode: mode, Value: c.A}
 	}
 
 	// unknown addressing mode
-	return 0, "???"
+	return Operand{Mode: mode}
 }
 
-// Execute provides a generic framework for executing a single instruction
-func (c *Cpu) Execute(inst Instruction) {
-
-	// get value based on addressing mode
-	value, format := c.getValueByMode(inst.Mode)
+// writeBack stores result to wherever op was read from: the accumulator for
+// MODE_ACC, or the bus for every other memory-referencing mode.
+func (c *Cpu) writeBack(op Operand, result uint8) {
+	if op.Mode == MODE_ACC {
+		c.A = result
+	} else {
+		c.Bus.Write(op.Addr, result)
+	}
+}
 
-	// debug
-	fmt.Printf(inst.Name+" "+format+" ", value)
+// branch takes the branch to target when taken is true, returning the extra
+// cycles earned (1 for a taken branch, 2 if it also crosses a page).
+func (c *Cpu) branch(taken bool, target uint16) int {
+	if !taken {
+		return 0
+	}
+	from := c.PC
+	c.PC = target
+	if from&0xff00 != target&0xff00 {
+		return 2
+	}
+	return 1
+}
 
-	// execute
-	inst.Execute(c, value)
+// setNZ updates the Negative and Zero flags from value.
+func (c *Cpu) setNZ(value uint8) {
+	c.Flags.N = value&0x80 == 0x80
+	c.Flags.Z = value == 0
+}
 
-	// increment PC
-	c.PC = c.PC + inst.Length
+// compare implements the CMP/CPX/CPY family: reg - value, without storing
+// the result.
+func (c *Cpu) compare(reg uint8, value uint8) {
+	result := reg - value
+	c.Flags.C = reg >= value
+	c.setNZ(result)
 }
 
-// Print displays cpu registers and relevant info
-func (c *Cpu) Print() {
+// Execute runs inst against op, returning any extra cycles the instruction
+// earned. Structured trace output is the Tracer's job (see trace.go); this
+// just dispatches.
+func (c *Cpu) Execute(inst Instruction, op Operand) int {
+	return inst.Execute(c, op)
+}
 
-	// FIXME: this could be written better
-	flags := ""
-	if c.Flags.N {
-		flags = flags + "N"
-	}
-	if c.Flags.Z {
-		flags = flags + "Z"
-	}
+// adcCore performs the binary add-with-carry shared by ADC and SBC (SBC
+// calls it with the ones' complement of its operand, the standard trick
+// that turns subtraction into addition). It sets C from the 9-bit sum and
+// V from the signed-overflow check; decimal mode correction, if any, is
+// layered on top by the caller.
+func (c *Cpu) adcCore(value uint8) uint8 {
+	carryIn := uint8(0)
 	if c.Flags.C {
-		flags = flags + "C"
+		carryIn = 1
+	}
+
+	sum := uint16(c.A) + uint16(value) + uint16(carryIn)
+	result := uint8(sum)
+
+	c.Flags.V = (^(c.A^value)&(c.A^result))&0x80 != 0
+	c.Flags.C = sum > 0xff
+
+	return result
+}
+
+// decimalAdd re-does the low/high nibble addition in BCD, overriding the
+// binary carry/result adcCore computed. See http://www.6502.org/tutorials/decimal_mode.html
+func (c *Cpu) decimalAdd(value, carryIn uint8) uint8 {
+	al := uint16(c.A&0x0f) + uint16(value&0x0f) + uint16(carryIn)
+	if al >= 0x0a {
+		al = ((al + 0x06) & 0x0f) + 0x10
 	}
-	if c.Flags.I {
-		flags = flags + "I"
+
+	aTemp := uint16(c.A&0xf0) + uint16(value&0xf0) + al
+	if aTemp >= 0xa0 {
+		aTemp += 0x60
 	}
-	if c.Flags.D {
-		flags = flags + "D"
+
+	c.Flags.C = aTemp >= 0x100
+	return uint8(aTemp)
+}
+
+// decimalSub is decimalAdd's mirror image for SBC: nibble borrows instead
+// of nibble carries. C is left as adcCore set it (from the binary result).
+func (c *Cpu) decimalSub(value, carryIn uint8) uint8 {
+	al := int32(c.A&0x0f) - int32(value&0x0f) + int32(carryIn) - 1
+	if al < 0 {
+		al = ((al - 0x06) & 0x0f) - 0x10
 	}
-	if c.Flags.V {
-		flags = flags + "V"
+
+	aTemp := int32(c.A&0xf0) - int32(value&0xf0) + al
+	if aTemp < 0 {
+		aTemp -= 0x60
 	}
 
-	fmt.Printf("PC:%04x A:%02x X:%02x Y:%02x SP:%02x FLAGS:%s\n",
-		c.PC, c.A, c.X, c.Y, c.SP, flags)
+	return uint8(aTemp)
 }
 
 // ExecuteAdc - Add memory to accumulator with carry - A + M + C -> A, C
-func ExecuteAdc(c *Cpu, value uint8) {
-	// store initial A value
-	initial := c.A
-
-	// add with carry
-	c.A = c.A + value
+func ExecuteAdc(c *Cpu, op Operand) int {
+	carryIn := uint8(0)
 	if c.Flags.C {
-		c.A = c.A + 1
+		carryIn = 1
+	}
+
+	result := c.adcCore(op.Value)
+	if c.Flags.D && c.Variant.DecimalModeSupported {
+		result = c.decimalAdd(op.Value, carryIn)
 	}
 
-	// check for carry
-	c.Flags.C = c.A < initial
+	c.A = result
+	c.setNZ(c.A)
+	return 0
+}
 
-	// check for overflow
-	c.Flags.V = ((initial^value)&0x80) > 0 && ((initial^c.A)&0x80) > 0
+// ExecuteSbc - Subtract memory from accumulator with borrow - A - M - ~C -> A
+func ExecuteSbc(c *Cpu, op Operand) int {
+	carryIn := uint8(0)
+	if c.Flags.C {
+		carryIn = 1
+	}
 
-	// update Negative flag
-	c.Flags.N = ((c.A & 0x80) == 0x80)
+	result := c.adcCore(^op.Value)
+	if c.Flags.D && c.Variant.DecimalModeSupported {
+		result = c.decimalSub(op.Value, carryIn)
+	}
 
-	// update Zero flag
-	c.Flags.Z = c.A == 0
+	c.A = result
+	c.setNZ(c.A)
+	return 0
 }
 
 // ExecuteAnd - "AND" memory with accumulator - A & M -> A
-func ExecuteAnd(c *Cpu, value uint8) {
+func ExecuteAnd(c *Cpu, op Operand) int {
 	// and value
-	c.A = c.A & value
-
-	// update Negative flag
-	c.Flags.N = ((c.A & 0x80) == 0x80)
+	c.A = c.A & op.Value
 
-	// update Zero flag
-	c.Flags.Z = c.A == 0
+	c.setNZ(c.A)
+	return 0
 }
 
 // ExecuteOra
-func ExecuteOra(c *Cpu, value uint8) {
-	// clobber flags
-	c.Flags.N, c.Flags.Z = false, false
-
+func ExecuteOra(c *Cpu, op Operand) int {
 	// or value
-	c.A = c.A | value
-
-	// update Negative flag
-	c.Flags.N = ((c.A & 0x80) == 0x80)
+	c.A = c.A | op.Value
 
-	// update Zero flag
-	c.Flags.Z = c.A == 0
+	c.setNZ(c.A)
+	return 0
 }
 
-// ExecuteAsl
-func ExecuteAsl(c *Cpu, value uint8) {
-	// clobber flags
-	c.Flags.N, c.Flags.Z, c.Flags.C = false, false, false
+// ExecuteEor - "Exclusive-OR" memory with accumulator - A ^ M -> A
+func ExecuteEor(c *Cpu, op Operand) int {
+	c.A = c.A ^ op.Value
+
+	c.setNZ(c.A)
+	return 0
+}
 
-	// determine if carry should be set
-	c.Flags.C = value&0x80 == 0x80
+// ExecuteAsl - Shift Left One Bit - C <- [76543210] <- 0
+func ExecuteAsl(c *Cpu, op Operand) int {
+	result := op.Value << 1
+	c.Flags.C = op.Value&0x80 == 0x80
+	c.writeBack(op, result)
 
-	// rotate left by 1 and mask out low bit
-	bits.RotateLeft8(value&0xfe, 1)
+	c.setNZ(result)
+	return 0
+}
 
-	// update Negative flag
-	c.Flags.N = ((c.A & 0x80) == 0x80)
+// ExecuteLsr - Shift Right One Bit - 0 -> [76543210] -> C
+func ExecuteLsr(c *Cpu, op Operand) int {
+	result := op.Value >> 1
+	c.Flags.C = op.Value&0x01 == 0x01
+	c.writeBack(op, result)
 
-	// update Zero flag
-	c.Flags.Z = c.A == 0
+	c.setNZ(result)
+	return 0
 }
 
-func ExecuteBcc(c *Cpu, value uint8) {
-	// branch on C == 0
-	if !c.Flags.C {
-		// value needs to be signed
-		//c.PC = c.PC + value
+// ExecuteRol - Rotate One Bit Left - C <- [76543210] <- C
+func ExecuteRol(c *Cpu, op Operand) int {
+	var carryIn uint8
+	if c.Flags.C {
+		carryIn = 1
 	}
+	result := (op.Value << 1) | carryIn
+	c.Flags.C = op.Value&0x80 == 0x80
+	c.writeBack(op, result)
+
+	c.setNZ(result)
+	return 0
 }
 
-func ExecuteBcs(c *Cpu, value uint8) {
-	// branch on C == 1
+// ExecuteRor - Rotate One Bit Right - C -> [76543210] -> C
+func ExecuteRor(c *Cpu, op Operand) int {
+	var carryIn uint8
 	if c.Flags.C {
-		// value needs to be signed
-		//c.PC = c.PC + value
+		carryIn = 0x80
+	}
+	result := (op.Value >> 1) | carryIn
+	c.Flags.C = op.Value&0x01 == 0x01
+	c.writeBack(op, result)
+
+	c.setNZ(result)
+	return 0
+}
+
+func ExecuteBcc(c *Cpu, op Operand) int {
+	return c.branch(!c.Flags.C, op.Addr)
+}
 
+func ExecuteBcs(c *Cpu, op Operand) int {
+	return c.branch(c.Flags.C, op.Addr)
+}
+
+// ExecuteBeq - Branch on Z == 1
+func ExecuteBeq(c *Cpu, op Operand) int {
+	return c.branch(c.Flags.Z, op.Addr)
+}
+
+// ExecuteBne - Branch on Z == 0
+func ExecuteBne(c *Cpu, op Operand) int {
+	return c.branch(!c.Flags.Z, op.Addr)
+}
+
+// ExecuteBmi - Branch on N == 1
+func ExecuteBmi(c *Cpu, op Operand) int {
+	return c.branch(c.Flags.N, op.Addr)
+}
+
+// ExecuteBpl - Branch on N == 0
+func ExecuteBpl(c *Cpu, op Operand) int {
+	return c.branch(!c.Flags.N, op.Addr)
+}
+
+// ExecuteBit - Test Bits in memory with accumulator. N and V are loaded
+// straight from bits 7 and 6 of the fetched byte; Z reflects A & M. On the
+// 65C02 the immediate form (no memory fetched, so no N/V source bits) only
+// affects Z.
+func ExecuteBit(c *Cpu, op Operand) int {
+	if op.Mode != MODE_IMM {
+		c.Flags.N = op.Value&0x80 != 0
+		c.Flags.V = op.Value&0x40 != 0
 	}
+	c.Flags.Z = c.A&op.Value == 0
+	return 0
+}
+
+// ExecuteBvc - Branch on V == 0
+func ExecuteBvc(c *Cpu, op Operand) int {
+	return c.branch(!c.Flags.V, op.Addr)
+}
+
+// ExecuteBvs - Branch on V == 1
+func ExecuteBvs(c *Cpu, op Operand) int {
+	return c.branch(c.Flags.V, op.Addr)
 }
 
 // ExecuteClc
-func ExecuteClc(c *Cpu, value uint8) {
+func ExecuteClc(c *Cpu, op Operand) int {
 	c.Flags.C = false
+	return 0
 }
 
 // ExecuteCld
-func ExecuteCld(c *Cpu, value uint8) {
+func ExecuteCld(c *Cpu, op Operand) int {
 	c.Flags.D = false
+	return 0
 }
 
 // ExecuteCli
-func ExecuteCli(c *Cpu, value uint8) {
+func ExecuteCli(c *Cpu, op Operand) int {
 	c.Flags.I = false
+	return 0
 }
 
 // ExecuteClv
-func ExecuteClv(c *Cpu, value uint8) {
+func ExecuteClv(c *Cpu, op Operand) int {
 	c.Flags.V = false
+	return 0
+}
+
+// ExecuteSec
+func ExecuteSec(c *Cpu, op Operand) int {
+	c.Flags.C = true
+	return 0
+}
+
+// ExecuteSed
+func ExecuteSed(c *Cpu, op Operand) int {
+	c.Flags.D = true
+	return 0
+}
+
+// ExecuteSei
+func ExecuteSei(c *Cpu, op Operand) int {
+	c.Flags.I = true
+	return 0
+}
+
+// ExecuteCmp - Compare Memory with Accumulator - A - M
+func ExecuteCmp(c *Cpu, op Operand) int {
+	c.compare(c.A, op.Value)
+	return 0
+}
+
+// ExecuteCpx - Compare Memory with X - X - M
+func ExecuteCpx(c *Cpu, op Operand) int {
+	c.compare(c.X, op.Value)
+	return 0
+}
+
+// ExecuteCpy - Compare Memory with Y - Y - M
+func ExecuteCpy(c *Cpu, op Operand) int {
+	c.compare(c.Y, op.Value)
+	return 0
+}
+
+// ExecuteInc - Increment Memory by One
+func ExecuteInc(c *Cpu, op Operand) int {
+	result := op.Value + 1
+	c.Bus.Write(op.Addr, result)
+
+	c.setNZ(result)
+	return 0
+}
+
+// ExecuteDec - Decrement Memory by One
+func ExecuteDec(c *Cpu, op Operand) int {
+	result := op.Value - 1
+	c.Bus.Write(op.Addr, result)
+
+	c.setNZ(result)
+	return 0
+}
+
+// ExecuteInx
+func ExecuteInx(c *Cpu, op Operand) int {
+	c.X = c.X + 1
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecuteIny
+func ExecuteIny(c *Cpu, op Operand) int {
+	c.Y = c.Y + 1
+	c.setNZ(c.Y)
+	return 0
+}
+
+// ExecuteDex
+func ExecuteDex(c *Cpu, op Operand) int {
+	c.X = c.X - 1
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecuteDey
+func ExecuteDey(c *Cpu, op Operand) int {
+	c.Y = c.Y - 1
+	c.setNZ(c.Y)
+	return 0
+}
+
+// ExecuteLda - Load Accumulator with Memory - M -> A
+func ExecuteLda(c *Cpu, op Operand) int {
+	c.A = op.Value
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecuteLdx - Load X with Memory - M -> X
+func ExecuteLdx(c *Cpu, op Operand) int {
+	c.X = op.Value
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecuteLdy - Load Y with Memory - M -> Y
+func ExecuteLdy(c *Cpu, op Operand) int {
+	c.Y = op.Value
+	c.setNZ(c.Y)
+	return 0
+}
+
+// ExecuteSta - Store Accumulator in Memory - A -> M
+func ExecuteSta(c *Cpu, op Operand) int {
+	c.Bus.Write(op.Addr, c.A)
+	return 0
+}
+
+// ExecuteStx - Store X in Memory - X -> M
+func ExecuteStx(c *Cpu, op Operand) int {
+	c.Bus.Write(op.Addr, c.X)
+	return 0
+}
+
+// ExecuteSty - Store Y in Memory - Y -> M
+func ExecuteSty(c *Cpu, op Operand) int {
+	c.Bus.Write(op.Addr, c.Y)
+	return 0
+}
+
+// ExecuteNop - No Operation
+func ExecuteNop(c *Cpu, op Operand) int {
+	return 0
+}
+
+// ExecuteTax - Transfer Accumulator to X - A -> X
+func ExecuteTax(c *Cpu, op Operand) int {
+	c.X = c.A
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecuteTxa - Transfer X to Accumulator - X -> A
+func ExecuteTxa(c *Cpu, op Operand) int {
+	c.A = c.X
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecuteTay - Transfer Accumulator to Y - A -> Y
+func ExecuteTay(c *Cpu, op Operand) int {
+	c.Y = c.A
+	c.setNZ(c.Y)
+	return 0
+}
+
+// ExecuteTya - Transfer Y to Accumulator - Y -> A
+func ExecuteTya(c *Cpu, op Operand) int {
+	c.A = c.Y
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecuteTsx - Transfer Stack Pointer to X - SP -> X
+func ExecuteTsx(c *Cpu, op Operand) int {
+	c.X = c.SP
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecuteTxs - Transfer X to Stack Pointer - X -> SP
+func ExecuteTxs(c *Cpu, op Operand) int {
+	c.SP = c.X
+	return 0
+}
+
+// ExecutePha - Push Accumulator on Stack
+func ExecutePha(c *Cpu, op Operand) int {
+	c.push(c.A)
+	return 0
+}
+
+// ExecutePla - Pull Accumulator from Stack
+func ExecutePla(c *Cpu, op Operand) int {
+	c.A = c.pull()
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecutePhp - Push Processor Status on Stack
+func ExecutePhp(c *Cpu, op Operand) int {
+	flags := c.Flags
+	flags.B = true
+	c.push(flags.ToByte())
+	return 0
+}
+
+// ExecutePlp - Pull Processor Status from Stack
+func ExecutePlp(c *Cpu, op Operand) int {
+	c.Flags = FlagsFromByte(c.pull())
+	return 0
+}
+
+// ExecuteJmp - Jump to New Location
+func ExecuteJmp(c *Cpu, op Operand) int {
+	c.PC = op.Addr
+	return 0
+}
+
+// ExecuteJsr - Jump to New Location Saving Return Address
+func ExecuteJsr(c *Cpu, op Operand) int {
+	// c.PC already points past this (3-byte) instruction; the address
+	// pushed is that of the last byte of JSR itself.
+	retAddr := c.PC - 1
+	c.push(uint8(retAddr >> 8))
+	c.push(uint8(retAddr & 0xff))
+	c.PC = op.Addr
+	return 0
+}
+
+// ExecuteRts - Return from Subroutine
+func ExecuteRts(c *Cpu, op Operand) int {
+	lo := c.pull()
+	hi := c.pull()
+	c.PC = (uint16(hi)<<8 | uint16(lo)) + 1
+	return 0
+}
+
+// ExecuteBrk - Force Break
+func ExecuteBrk(c *Cpu, op Operand) int {
+	// c.PC already points past the 2-byte BRK instruction (opcode + padding
+	// byte), which is the return address pushed.
+	c.pushInterruptFrame(irqVector, true)
+	return 0
+}
+
+// ExecuteRti - Return from Interrupt
+func ExecuteRti(c *Cpu, op Operand) int {
+	c.Flags = FlagsFromByte(c.pull())
+	lo := c.pull()
+	hi := c.pull()
+	c.PC = uint16(hi)<<8 | uint16(lo)
+	return 0
+}
+
+// ExecuteBra - 65C02 only - Branch Always
+func ExecuteBra(c *Cpu, op Operand) int {
+	return c.branch(true, op.Addr)
+}
+
+// ExecuteIncA - 65C02 only - Increment Accumulator - A + 1 -> A
+func ExecuteIncA(c *Cpu, op Operand) int {
+	c.A = c.A + 1
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecuteDecA - 65C02 only - Decrement Accumulator - A - 1 -> A
+func ExecuteDecA(c *Cpu, op Operand) int {
+	c.A = c.A - 1
+	c.setNZ(c.A)
+	return 0
+}
+
+// ExecutePhx - 65C02 only - Push X onto stack
+func ExecutePhx(c *Cpu, op Operand) int {
+	c.push(c.X)
+	return 0
+}
+
+// ExecutePhy - 65C02 only - Push Y onto stack
+func ExecutePhy(c *Cpu, op Operand) int {
+	c.push(c.Y)
+	return 0
+}
+
+// ExecutePlx - 65C02 only - Pull X from stack
+func ExecutePlx(c *Cpu, op Operand) int {
+	c.X = c.pull()
+	c.setNZ(c.X)
+	return 0
+}
+
+// ExecutePly - 65C02 only - Pull Y from stack
+func ExecutePly(c *Cpu, op Operand) int {
+	c.Y = c.pull()
+	c.setNZ(c.Y)
+	return 0
+}
+
+// ExecuteStz - 65C02 only - Store Zero to memory
+func ExecuteStz(c *Cpu, op Operand) int {
+	c.Bus.Write(op.Addr, 0)
+	return 0
+}
+
+// ExecuteTrb - 65C02 only - Test and Reset Bits - M & ~A -> M, Z from M & A
+func ExecuteTrb(c *Cpu, op Operand) int {
+	c.Flags.Z = op.Value&c.A == 0
+	c.Bus.Write(op.Addr, op.Value&^c.A)
+	return 0
+}
+
+// ExecuteTsb - 65C02 only - Test and Set Bits - M | A -> M, Z from M & A
+func ExecuteTsb(c *Cpu, op Operand) int {
+	c.Flags.Z = op.Value&c.A == 0
+	c.Bus.Write(op.Addr, op.Value|c.A)
+	return 0
 }
 
 type Instruction struct {
-	Name    string            // 3-letter name of instruction
-	Mode    AddressingMode    // Instruction mode of opcode
-	Length  uint16            // Bytes of instruction
-	Cycles  int               // Cycles instructions takes to execute (at best)
-	Execute func(*Cpu, uint8) // Execution function
+	Name    string                  // 3-letter name of instruction
+	Mode    AddressingMode          // Instruction mode of opcode
+	Length  uint16                  // Bytes of instruction
+	Cycles  int                     // Cycles instructions takes to execute (at best)
+	Execute func(*Cpu, Operand) int // Execution function, returns extra cycles earned
+}
+
+// pageCrossReadOps earn an extra cycle when their ABX/ABY/IZY addressing
+// crosses a page boundary. Stores and read-modify-write instructions always
+// take their listed (worst-case) cycle count instead.
+var pageCrossReadOps = map[string]bool{
+	"LDA": true, "LDX": true, "LDY": true,
+	"ADC": true, "SBC": true, "AND": true, "CMP": true, "BIT": true,
+	"CPX": true, "CPY": true, "EOR": true, "ORA": true,
 }
 
 // http://nesdev.com/6502.txt
-var instructionMap = map[uint8]Instruction{
+var nmosInstructions = map[uint8]Instruction{
 
 	0x69: Instruction{"ADC", MODE_IMM, 2, 2, ExecuteAdc},
 	0x65: Instruction{"ADC", MODE_ZEP, 2, 3, ExecuteAdc},
 	0x75: Instruction{"ADC", MODE_ZPX, 2, 4, ExecuteAdc},
-	0x60: Instruction{"ADC", MODE_ABS, 3, 4, ExecuteAdc},
-	0x70: Instruction{"ADC", MODE_ABX, 3, 4, ExecuteAdc},
+	0x6D: Instruction{"ADC", MODE_ABS, 3, 4, ExecuteAdc},
+	0x7D: Instruction{"ADC", MODE_ABX, 3, 4, ExecuteAdc},
 	0x79: Instruction{"ADC", MODE_ABY, 3, 4, ExecuteAdc},
 	0x61: Instruction{"ADC", MODE_IZX, 2, 6, ExecuteAdc},
 	0x71: Instruction{"ADC", MODE_IZY, 2, 5, ExecuteAdc},
 
+	0xe9: Instruction{"SBC", MODE_IMM, 2, 2, ExecuteSbc},
+	0xe5: Instruction{"SBC", MODE_ZEP, 2, 3, ExecuteSbc},
+	0xf5: Instruction{"SBC", MODE_ZPX, 2, 4, ExecuteSbc},
+	0xed: Instruction{"SBC", MODE_ABS, 3, 4, ExecuteSbc},
+	0xfd: Instruction{"SBC", MODE_ABX, 3, 4, ExecuteSbc},
+	0xf9: Instruction{"SBC", MODE_ABY, 3, 4, ExecuteSbc},
+	0xe1: Instruction{"SBC", MODE_IZX, 2, 6, ExecuteSbc},
+	0xf1: Instruction{"SBC", MODE_IZY, 2, 5, ExecuteSbc},
+
 	0x29: Instruction{"AND", MODE_IMM, 2, 2, ExecuteAnd},
 	0x25: Instruction{"AND", MODE_ZEP, 2, 3, ExecuteAnd},
 	0x35: Instruction{"AND", MODE_ZPX, 2, 4, ExecuteAnd},
@@ -268,6 +832,9 @@ var instructionMap = map[uint8]Instruction{
 	0x21: Instruction{"AND", MODE_IZX, 2, 6, ExecuteAnd},
 	0x31: Instruction{"AND", MODE_IZY, 2, 5, ExecuteAnd},
 
+	0x24: Instruction{"BIT", MODE_ZEP, 2, 3, ExecuteBit},
+	0x2c: Instruction{"BIT", MODE_ABS, 3, 4, ExecuteBit},
+
 	0x0a: Instruction{"ASL", MODE_ACC, 1, 2, ExecuteAsl},
 	0x06: Instruction{"ASL", MODE_ZEP, 2, 5, ExecuteAsl},
 	0x16: Instruction{"ASL", MODE_ZPX, 2, 6, ExecuteAsl},
@@ -276,22 +843,22 @@ var instructionMap = map[uint8]Instruction{
 
 	0x90: Instruction{"BCC", MODE_REL, 2, 2, ExecuteBcc},
 	0xb0: Instruction{"BCS", MODE_REL, 2, 2, ExecuteBcs},
-	/*0xf0: Instruction{"BEQ", MODE_REL, 2, 2, ExecuteBeq},
-
-	0x24: Instruction{"BIT", MODE_ZEP, 2, 3, ExecuteBit},
-	0x2c: Instruction{"BIT", MODE_ABS, 3, 4, ExecuteBit},
-
+	0xf0: Instruction{"BEQ", MODE_REL, 2, 2, ExecuteBeq},
 	0x30: Instruction{"BMI", MODE_REL, 2, 2, ExecuteBmi},
 	0xd0: Instruction{"BNE", MODE_REL, 2, 2, ExecuteBne},
 	0x10: Instruction{"BPL", MODE_REL, 2, 2, ExecuteBpl},
-	0x00: Instruction{"BRK", MODE_IMP, 1, 7, ExecuteBrk},
 	0x50: Instruction{"BVC", MODE_REL, 2, 2, ExecuteBvc},
 	0x70: Instruction{"BVS", MODE_REL, 2, 2, ExecuteBvs},
 
+	0x00: Instruction{"BRK", MODE_IMP, 2, 7, ExecuteBrk},
+
 	0x18: Instruction{"CLC", MODE_IMP, 1, 2, ExecuteClc},
 	0xd8: Instruction{"CLD", MODE_IMP, 1, 2, ExecuteCld},
 	0x58: Instruction{"CLI", MODE_IMP, 1, 2, ExecuteCli},
 	0xb8: Instruction{"CLV", MODE_IMP, 1, 2, ExecuteClv},
+	0x38: Instruction{"SEC", MODE_IMP, 1, 2, ExecuteSec},
+	0xf8: Instruction{"SED", MODE_IMP, 1, 2, ExecuteSed},
+	0x78: Instruction{"SEI", MODE_IMP, 1, 2, ExecuteSei},
 
 	0xc9: Instruction{"CMP", MODE_IMM, 2, 2, ExecuteCmp},
 	0xc5: Instruction{"CMP", MODE_ZEP, 2, 3, ExecuteCmp},
@@ -302,11 +869,11 @@ var instructionMap = map[uint8]Instruction{
 	0xc1: Instruction{"CMP", MODE_IZX, 2, 6, ExecuteCmp},
 	0xd1: Instruction{"CMP", MODE_IZY, 2, 5, ExecuteCmp},
 
-	0xe0: Instruction{"CPX", MODE_IMM, 2, 5, ExecuteCpx},
+	0xe0: Instruction{"CPX", MODE_IMM, 2, 2, ExecuteCpx},
 	0xe4: Instruction{"CPX", MODE_ZEP, 2, 3, ExecuteCpx},
 	0xec: Instruction{"CPX", MODE_ABS, 3, 4, ExecuteCpx},
 
-	0xc0: Instruction{"CPY", MODE_IMM, 2, 5, ExecuteCpy},
+	0xc0: Instruction{"CPY", MODE_IMM, 2, 2, ExecuteCpy},
 	0xc4: Instruction{"CPY", MODE_ZEP, 2, 3, ExecuteCpy},
 	0xcc: Instruction{"CPY", MODE_ABS, 3, 4, ExecuteCpy},
 
@@ -315,20 +882,25 @@ var instructionMap = map[uint8]Instruction{
 	0xce: Instruction{"DEC", MODE_ABS, 3, 6, ExecuteDec},
 	0xde: Instruction{"DEC", MODE_ABX, 3, 7, ExecuteDec},
 
+	0xe6: Instruction{"INC", MODE_ZEP, 2, 5, ExecuteInc},
+	0xf6: Instruction{"INC", MODE_ZPX, 2, 6, ExecuteInc},
+	0xee: Instruction{"INC", MODE_ABS, 3, 6, ExecuteInc},
+	0xfe: Instruction{"INC", MODE_ABX, 3, 7, ExecuteInc},
+
+	0xe8: Instruction{"INX", MODE_IMP, 1, 2, ExecuteInx},
+	0xc8: Instruction{"INY", MODE_IMP, 1, 2, ExecuteIny},
 	0xca: Instruction{"DEX", MODE_IMP, 1, 2, ExecuteDex},
 	0x88: Instruction{"DEY", MODE_IMP, 1, 2, ExecuteDey},
 
 	0x49: Instruction{"EOR", MODE_IMM, 2, 2, ExecuteEor},
 	0x45: Instruction{"EOR", MODE_ZEP, 2, 3, ExecuteEor},
 	0x55: Instruction{"EOR", MODE_ZPX, 2, 4, ExecuteEor},
-	0x40: Instruction{"EOR", MODE_ABS, 3, 4, ExecuteEor},
-	0x50: Instruction{"EOR", MODE_ABX, 3, 4, ExecuteEor},
+	0x4D: Instruction{"EOR", MODE_ABS, 3, 4, ExecuteEor},
+	0x5D: Instruction{"EOR", MODE_ABX, 3, 4, ExecuteEor},
 	0x59: Instruction{"EOR", MODE_ABY, 3, 4, ExecuteEor},
 	0x41: Instruction{"EOR", MODE_IZX, 2, 6, ExecuteEor},
 	0x51: Instruction{"EOR", MODE_IZY, 2, 5, ExecuteEor},
-	*/
 
-	//
 	0x09: Instruction{"ORA", MODE_IMM, 2, 2, ExecuteOra},
 	0x05: Instruction{"ORA", MODE_ZEP, 2, 3, ExecuteOra},
 	0x15: Instruction{"ORA", MODE_ZPX, 2, 4, ExecuteOra},
@@ -337,10 +909,79 @@ var instructionMap = map[uint8]Instruction{
 	0x19: Instruction{"ORA", MODE_ABY, 3, 4, ExecuteOra},
 	0x01: Instruction{"ORA", MODE_IZX, 2, 6, ExecuteOra},
 	0x11: Instruction{"ORA", MODE_IZY, 2, 5, ExecuteOra},
-}
 
-func (c *Cpu) Step() {
-	inst := instructionMap[c.Memory[c.PC]]
-	c.Execute(inst)
-	c.Print()
+	0xa9: Instruction{"LDA", MODE_IMM, 2, 2, ExecuteLda},
+	0xa5: Instruction{"LDA", MODE_ZEP, 2, 3, ExecuteLda},
+	0xb5: Instruction{"LDA", MODE_ZPX, 2, 4, ExecuteLda},
+	0xad: Instruction{"LDA", MODE_ABS, 3, 4, ExecuteLda},
+	0xbd: Instruction{"LDA", MODE_ABX, 3, 4, ExecuteLda},
+	0xb9: Instruction{"LDA", MODE_ABY, 3, 4, ExecuteLda},
+	0xa1: Instruction{"LDA", MODE_IZX, 2, 6, ExecuteLda},
+	0xb1: Instruction{"LDA", MODE_IZY, 2, 5, ExecuteLda},
+
+	0xa2: Instruction{"LDX", MODE_IMM, 2, 2, ExecuteLdx},
+	0xa6: Instruction{"LDX", MODE_ZEP, 2, 3, ExecuteLdx},
+	0xb6: Instruction{"LDX", MODE_ZPY, 2, 4, ExecuteLdx},
+	0xae: Instruction{"LDX", MODE_ABS, 3, 4, ExecuteLdx},
+	0xbe: Instruction{"LDX", MODE_ABY, 3, 4, ExecuteLdx},
+
+	0xa0: Instruction{"LDY", MODE_IMM, 2, 2, ExecuteLdy},
+	0xa4: Instruction{"LDY", MODE_ZEP, 2, 3, ExecuteLdy},
+	0xb4: Instruction{"LDY", MODE_ZPX, 2, 4, ExecuteLdy},
+	0xac: Instruction{"LDY", MODE_ABS, 3, 4, ExecuteLdy},
+	0xbc: Instruction{"LDY", MODE_ABX, 3, 4, ExecuteLdy},
+
+	0x85: Instruction{"STA", MODE_ZEP, 2, 3, ExecuteSta},
+	0x95: Instruction{"STA", MODE_ZPX, 2, 4, ExecuteSta},
+	0x8d: Instruction{"STA", MODE_ABS, 3, 4, ExecuteSta},
+	0x9d: Instruction{"STA", MODE_ABX, 3, 5, ExecuteSta},
+	0x99: Instruction{"STA", MODE_ABY, 3, 5, ExecuteSta},
+	0x81: Instruction{"STA", MODE_IZX, 2, 6, ExecuteSta},
+	0x91: Instruction{"STA", MODE_IZY, 2, 6, ExecuteSta},
+
+	0x86: Instruction{"STX", MODE_ZEP, 2, 3, ExecuteStx},
+	0x96: Instruction{"STX", MODE_ZPY, 2, 4, ExecuteStx},
+	0x8e: Instruction{"STX", MODE_ABS, 3, 4, ExecuteStx},
+
+	0x84: Instruction{"STY", MODE_ZEP, 2, 3, ExecuteSty},
+	0x94: Instruction{"STY", MODE_ZPX, 2, 4, ExecuteSty},
+	0x8c: Instruction{"STY", MODE_ABS, 3, 4, ExecuteSty},
+
+	0x4a: Instruction{"LSR", MODE_ACC, 1, 2, ExecuteLsr},
+	0x46: Instruction{"LSR", MODE_ZEP, 2, 5, ExecuteLsr},
+	0x56: Instruction{"LSR", MODE_ZPX, 2, 6, ExecuteLsr},
+	0x4e: Instruction{"LSR", MODE_ABS, 3, 6, ExecuteLsr},
+	0x5e: Instruction{"LSR", MODE_ABX, 3, 7, ExecuteLsr},
+
+	0x2a: Instruction{"ROL", MODE_ACC, 1, 2, ExecuteRol},
+	0x26: Instruction{"ROL", MODE_ZEP, 2, 5, ExecuteRol},
+	0x36: Instruction{"ROL", MODE_ZPX, 2, 6, ExecuteRol},
+	0x2e: Instruction{"ROL", MODE_ABS, 3, 6, ExecuteRol},
+	0x3e: Instruction{"ROL", MODE_ABX, 3, 7, ExecuteRol},
+
+	0x6a: Instruction{"ROR", MODE_ACC, 1, 2, ExecuteRor},
+	0x66: Instruction{"ROR", MODE_ZEP, 2, 5, ExecuteRor},
+	0x76: Instruction{"ROR", MODE_ZPX, 2, 6, ExecuteRor},
+	0x6e: Instruction{"ROR", MODE_ABS, 3, 6, ExecuteRor},
+	0x7e: Instruction{"ROR", MODE_ABX, 3, 7, ExecuteRor},
+
+	0x4c: Instruction{"JMP", MODE_ABS, 3, 3, ExecuteJmp},
+	0x6c: Instruction{"JMP", MODE_IND, 3, 5, ExecuteJmp},
+	0x20: Instruction{"JSR", MODE_ABS, 3, 6, ExecuteJsr},
+	0x60: Instruction{"RTS", MODE_IMP, 1, 6, ExecuteRts},
+	0x40: Instruction{"RTI", MODE_IMP, 1, 6, ExecuteRti},
+
+	0x48: Instruction{"PHA", MODE_IMP, 1, 3, ExecutePha},
+	0x68: Instruction{"PLA", MODE_IMP, 1, 4, ExecutePla},
+	0x08: Instruction{"PHP", MODE_IMP, 1, 3, ExecutePhp},
+	0x28: Instruction{"PLP", MODE_IMP, 1, 4, ExecutePlp},
+
+	0xaa: Instruction{"TAX", MODE_IMP, 1, 2, ExecuteTax},
+	0x8a: Instruction{"TXA", MODE_IMP, 1, 2, ExecuteTxa},
+	0xa8: Instruction{"TAY", MODE_IMP, 1, 2, ExecuteTay},
+	0x98: Instruction{"TYA", MODE_IMP, 1, 2, ExecuteTya},
+	0xba: Instruction{"TSX", MODE_IMP, 1, 2, ExecuteTsx},
+	0x9a: Instruction{"TXS", MODE_IMP, 1, 2, ExecuteTxs},
+
+	0xea: Instruction{"NOP", MODE_IMP, 1, 2, ExecuteNop},
 }
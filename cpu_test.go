@@ -10,8 +10,7 @@ func TestExecuteAdc(t *testing.T) {
 	// Add 0x10 to 0x10 with carry
 	c.A = 0x10
 	c.Flags.C = true
-	ExecuteAdc(&c, 0x10)
-	c.Print()
+	ExecuteAdc(&c, Operand{Value: 0x10})
 	if c.A != 0x21 {
 		t.Fail()
 	}
@@ -19,70 +18,300 @@ func TestExecuteAdc(t *testing.T) {
 	// Test Zero
 	c.A = 0x0
 	c.Flags.C = false
-	ExecuteAdc(&c, 0x0)
-	c.Print()
+	ExecuteAdc(&c, Operand{Value: 0x0})
 	if !c.Flags.Z {
 		t.Fail()
 	}
 
-	// Test Overflow
+	// Test Carry - wraps to zero, no signed overflow (-1 + 0 + 1 = 0)
 	c.A = 0xff
 	c.Flags.C = true
-	ExecuteAdc(&c, 0x0)
-	c.Print()
-	if !c.Flags.V || !c.Flags.C || !c.Flags.Z {
+	ExecuteAdc(&c, Operand{Value: 0x0})
+	if c.Flags.V || !c.Flags.C || !c.Flags.Z {
+		t.Fail()
+	}
+
+	// Test Overflow - two positives summing into a negative result
+	c.A = 0x7f
+	c.Flags.C = false
+	ExecuteAdc(&c, Operand{Value: 0x01})
+	if !c.Flags.V || c.Flags.C || c.A != 0x80 {
+		t.Fail()
+	}
+}
+
+func TestExecuteSbc(t *testing.T) {
+	c := Cpu{}
+
+	// 0x50 - 0xf0, with borrow (C clear going in)
+	c.A = 0x50
+	c.Flags.C = false
+	ExecuteSbc(&c, Operand{Value: 0xf0})
+	if c.A != 0x5f || c.Flags.C {
+		t.Fail()
+	}
+
+	// 0x00 - 0x01, always a borrow
+	c.A = 0x00
+	c.Flags.C = true
+	ExecuteSbc(&c, Operand{Value: 0x01})
+	if c.A != 0xff || c.Flags.C || !c.Flags.N {
+		t.Fail()
+	}
+
+	// 0x80 - 0x01, no borrow, signed overflow (negative - positive = positive)
+	c.A = 0x80
+	c.Flags.C = true
+	ExecuteSbc(&c, Operand{Value: 0x01})
+	if c.A != 0x7f || !c.Flags.C || !c.Flags.V {
 		t.Fail()
 	}
 }
 
+func TestAdcSbcDecimalMode(t *testing.T) {
+	c := Cpu{Variant: NMOSVariant}
+	c.Flags.D = true
+
+	// 0x99 + 0x01 with carry in -> 0x01 with carry out (rolls over 100)
+	c.A = 0x99
+	c.Flags.C = true
+	ExecuteAdc(&c, Operand{Value: 0x01})
+	if c.A != 0x01 || !c.Flags.C {
+		t.Fail()
+	}
+
+	// 0x00 - 0x01, no carry in (an extra borrow) -> 0x98 with borrow
+	c.A = 0x00
+	c.Flags.C = false
+	ExecuteSbc(&c, Operand{Value: 0x01})
+	if c.A != 0x98 || c.Flags.C {
+		t.Fail()
+	}
+
+	// 0x50 + 0x50, no carry in: 50+50 = 100, which rolls over to 0x00 with
+	// decimal carry out. V, however, is left as adcCore's binary-overflow
+	// check computed it (true here, from the pre-BCD-adjustment 0x50+0x50
+	// = 0xa0 signed overflow) - real 6502 hardware leaves V meaningless in
+	// decimal mode too, so this pins down that known quirk rather than a bug.
+	c.A = 0x50
+	c.Flags.C = false
+	ExecuteAdc(&c, Operand{Value: 0x50})
+	if c.A != 0x00 || !c.Flags.C || !c.Flags.Z || c.Flags.N || !c.Flags.V {
+		t.Fail()
+	}
+
+	// the Ricoh 2A03 has no decimal mode: D is set but ignored
+	ricoh := Cpu{Variant: Ricoh2A03Variant}
+	ricoh.Flags.D = true
+	ricoh.A = 0x99
+	ricoh.Flags.C = true
+	ExecuteAdc(&ricoh, Operand{Value: 0x01})
+	if ricoh.A != 0x9b {
+		t.Fail() // binary 0x99+0x01+1, not BCD-adjusted
+	}
+}
+
 func TestExecuteAnd(t *testing.T) {
 	c := Cpu{}
 
 	// Test simple and
 	c.A = 0xff
-	ExecuteAnd(&c, 0xff)
-	c.Print()
+	ExecuteAnd(&c, Operand{Value: 0xff})
 	if c.A != 0xff || !c.Flags.N {
 		t.Fail()
 	}
 
 	// Test and resulting in zero
 	c.A = 0xff
-	ExecuteAnd(&c, 0x00)
-	c.Print()
+	ExecuteAnd(&c, Operand{Value: 0x00})
 	if c.A != 0x00 || !c.Flags.Z {
 		t.Fail()
 	}
 }
 
+func TestExecuteBit(t *testing.T) {
+	c := Cpu{}
+
+	// N and V come from bits 7/6 of the operand; Z from A & M
+	c.A = 0xff
+	ExecuteBit(&c, Operand{Mode: MODE_ABS, Value: 0xc0})
+	if !c.Flags.N || !c.Flags.V || c.Flags.Z {
+		t.Fail()
+	}
+
+	c.A = 0xf0
+	ExecuteBit(&c, Operand{Mode: MODE_ZEP, Value: 0x0f})
+	if c.Flags.N || c.Flags.V || !c.Flags.Z {
+		t.Fail()
+	}
+
+	// 65C02 immediate form: no memory fetched, so N/V are left alone
+	c.A = 0x0f
+	c.Flags.N, c.Flags.V = true, true
+	ExecuteBit(&c, Operand{Mode: MODE_IMM, Value: 0xf0})
+	if !c.Flags.N || !c.Flags.V || !c.Flags.Z {
+		t.Fail()
+	}
+}
+
 func TestExecuteOra(t *testing.T) {
 	c := Cpu{}
 
 	// Test simple or
 	c.A = 0x00
-	ExecuteOra(&c, 0xff)
-	c.Print()
+	ExecuteOra(&c, Operand{Value: 0xff})
 	if c.A != 0xff || !c.Flags.N {
 		t.Fail()
 	}
 
 	// Test or resulting in zero
 	c.A = 0x00
-	ExecuteOra(&c, 0x00)
-	c.Print()
+	ExecuteOra(&c, Operand{Value: 0x00})
 	if c.A != 0x00 || !c.Flags.Z {
 		t.Fail()
 	}
 }
 
+func newTestCpu() *Cpu {
+	bus := NewMemoryBus()
+	bus.Attach(NewRam(), "RAM", 0x0000, 0xffff)
+	return NewNMOS6502(bus)
+}
+
+func TestGetOperandAddressingModes(t *testing.T) {
+	c := newTestCpu()
+	c.X, c.Y = 0x01, 0x02
+
+	// $10: ABS operand $0200 -> reads value at $0200
+	c.Bus.Write(0x10, 0x00)
+	c.Bus.Write(0x11, 0x02)
+	c.Bus.Write(0x0200, 0x42)
+	c.PC = 0x10 - 1 // getOperand reads from PC+1
+	op := c.getOperand(MODE_ABS)
+	if op.Addr != 0x0200 || op.Value != 0x42 {
+		t.Fail()
+	}
+
+	// ABX page-cross: base $02ff + X(1) = $0300
+	c.Bus.Write(0x20, 0xff)
+	c.Bus.Write(0x21, 0x02)
+	c.Bus.Write(0x0300, 0x99)
+	c.PC = 0x20 - 1
+	op = c.getOperand(MODE_ABX)
+	if op.Addr != 0x0300 || op.Value != 0x99 || !op.PageCrossed {
+		t.Fail()
+	}
+
+	// IZX: zp pointer at ($30+X=$31) -> addr $0400
+	c.Bus.Write(0x40, 0x30)
+	c.Bus.Write(0x31, 0x00)
+	c.Bus.Write(0x32, 0x04)
+	c.Bus.Write(0x0400, 0x55)
+	c.PC = 0x40 - 1
+	op = c.getOperand(MODE_IZX)
+	if op.Addr != 0x0400 || op.Value != 0x55 {
+		t.Fail()
+	}
+}
+
+func TestJmpIndirectNmosBug(t *testing.T) {
+	c := newTestCpu()
+
+	// pointer at $02ff/$0200 (the NMOS bug wraps the high byte fetch to
+	// the start of the same page instead of $0300)
+	c.Bus.Write(0x0000, 0xff)
+	c.Bus.Write(0x0001, 0x02)
+	c.Bus.Write(0x02ff, 0x00)
+	c.Bus.Write(0x0200, 0x80)
+	c.PC = 0xffff
+	op := c.getOperand(MODE_IND)
+	if op.Addr != 0x8000 {
+		t.Fail()
+	}
+}
+
+func TestBranchTakenAndPageCross(t *testing.T) {
+	c := newTestCpu()
+
+	c.PC = 0x00f0
+	extra := c.branch(true, 0x0105)
+	if c.PC != 0x0105 || extra != 2 {
+		t.Fail() // $00f0 -> $0105 crosses a page
+	}
+
+	c.PC = 0x0010
+	extra = c.branch(true, 0x0015)
+	if c.PC != 0x0015 || extra != 1 {
+		t.Fail()
+	}
+
+	c.PC = 0x0010
+	extra = c.branch(false, 0x0015)
+	if c.PC != 0x0010 || extra != 0 {
+		t.Fail()
+	}
+}
+
+func TestJsrRts(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+
+	// JSR $8000 at $0600
+	c.Bus.Write(0x0600, 0x20)
+	c.Bus.Write(0x0601, 0x00)
+	c.Bus.Write(0x0602, 0x80)
+	c.Bus.Write(0x8000, 0x60) // RTS
+	c.PC = 0x0600
+
+	cycles, err := c.Step()
+	if err != nil || c.PC != 0x8000 || cycles != 6 {
+		t.Fail()
+	}
+
+	cycles, err = c.Step()
+	if err != nil || c.PC != 0x0603 || cycles != 6 {
+		t.Fail()
+	}
+}
+
+func TestStepUnimplementedOpcode(t *testing.T) {
+	c := newTestCpu()
+	c.Bus.Write(0x0000, 0x02) // no opcode is mapped to 0x02
+	if _, err := c.Step(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestStepAccumulatesCycles(t *testing.T) {
+	c := newTestCpu()
+	c.Bus.Write(0x0000, 0x18) // CLC, 2 cycles
+	c.Step()
+	if c.Cycles != 2 {
+		t.Fail()
+	}
+}
+
+func TestExecuteNop(t *testing.T) {
+	c := newTestCpu()
+	c.Bus.Write(0x0000, 0xea) // NOP, 2 cycles, PC advances past it and nothing else changes
+	before := *c
+
+	cycles, err := c.Step()
+	if err != nil || cycles != 2 || c.PC != 0x0001 {
+		t.Fail()
+	}
+	if c.A != before.A || c.X != before.X || c.Y != before.Y || c.SP != before.SP || c.Flags != before.Flags {
+		t.Fail()
+	}
+}
+
 func TestExecuteCl(t *testing.T) {
 	c := Cpu{}
 	c.Flags.C, c.Flags.D, c.Flags.I, c.Flags.V = true, true, true, true
-	ExecuteClc(&c, 0)
-	ExecuteCld(&c, 0)
-	ExecuteCli(&c, 0)
-	ExecuteClv(&c, 0)
-	c.Print()
+	ExecuteClc(&c, Operand{})
+	ExecuteCld(&c, Operand{})
+	ExecuteCli(&c, Operand{})
+	ExecuteClv(&c, Operand{})
 	if c.Flags.C || c.Flags.D || c.Flags.I || c.Flags.V {
 		t.Fail()
 	}
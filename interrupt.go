@@ -0,0 +1,56 @@
+package main
+
+// Interrupt vectors, read through the Bus so cartridge mappers and any
+// future vector overlays (e.g. mapper IRQ redirection) are respected.
+const (
+	nmiVector   uint16 = 0xfffa
+	resetVector uint16 = 0xfffc
+	irqVector   uint16 = 0xfffe
+)
+
+// pushInterruptFrame pushes PC and P onto the stack and loads PC from
+// vector, the way BRK and a hardware NMI/IRQ both enter their handler. brk
+// selects the B flag value stored in the pushed P: set for BRK, clear for
+// NMI/IRQ so the handler can tell them apart.
+func (c *Cpu) pushInterruptFrame(vector uint16, brk bool) {
+	c.push(uint8(c.PC >> 8))
+	c.push(uint8(c.PC & 0xff))
+
+	flags := c.Flags
+	flags.B = brk
+	c.push(flags.ToByte())
+
+	c.Flags.I = true
+	if c.Variant.BRKClearsDecimal {
+		c.Flags.D = false
+	}
+
+	c.PC = c.Bus.Read16(vector)
+}
+
+// Reset emulates the RESET line: real hardware doesn't write to the stack
+// (it has no idea what's there yet), it just decrements SP by 3 as if it
+// had, sets I, and loads PC from the reset vector.
+func (c *Cpu) Reset() {
+	c.SP -= 3
+	c.Flags.I = true
+	c.PC = c.Bus.Read16(resetVector)
+}
+
+// NMI services a non-maskable interrupt: PPU vblank sets NMIPending, and
+// Step calls this between instructions. NMI cannot be masked by the I flag.
+func (c *Cpu) NMI() {
+	c.pushInterruptFrame(nmiVector, false)
+	c.NMIPending = false
+}
+
+// IRQ services a maskable interrupt (e.g. an APU frame IRQ). It is a no-op
+// if the I flag is set; IRQPending is left latched so Step retries it once
+// the mask is cleared.
+func (c *Cpu) IRQ() {
+	if c.Flags.I {
+		return
+	}
+	c.pushInterruptFrame(irqVector, false)
+	c.IRQPending = false
+}
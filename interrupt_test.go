@@ -0,0 +1,88 @@
+package main
+
+import "testing"
+
+func TestReset(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+	c.Bus.Write(0xfffc, 0x00)
+	c.Bus.Write(0xfffd, 0x80)
+
+	c.Reset()
+	if c.PC != 0x8000 || c.SP != 0xfa || !c.Flags.I {
+		t.Fail()
+	}
+}
+
+func TestNMI(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+	c.PC = 0x1234
+	c.Bus.Write(0xfffa, 0x00)
+	c.Bus.Write(0xfffb, 0x90)
+	c.NMIPending = true
+
+	cycles, err := c.Step()
+	if err != nil || c.PC != 0x9000 || cycles != 7 || c.NMIPending {
+		t.Fail()
+	}
+	if !c.Flags.I {
+		t.Fail()
+	}
+
+	// the pushed P has B clear, distinguishing NMI entry from BRK
+	p := c.Bus.Read(0x0100 + uint16(c.SP+1))
+	if p&0x10 != 0 {
+		t.Fail()
+	}
+}
+
+func TestIRQMaskedByI(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+	c.PC = 0x1234
+	c.Flags.I = true
+	c.IRQPending = true
+	c.Bus.Write(0x1234, 0x18) // CLC, so a masked IRQ just falls through to it
+
+	cycles, err := c.Step()
+	if err != nil || c.PC != 0x1235 || cycles != 2 || !c.IRQPending {
+		t.Fail() // still masked and still pending
+	}
+}
+
+func TestIRQTaken(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+	c.PC = 0x1234
+	c.Bus.Write(0xfffe, 0x00)
+	c.Bus.Write(0xffff, 0xa0)
+	c.IRQPending = true
+
+	cycles, err := c.Step()
+	if err != nil || c.PC != 0xa000 || cycles != 7 || c.IRQPending {
+		t.Fail()
+	}
+}
+
+func TestBrkRtiRoundTrip(t *testing.T) {
+	c := newTestCpu()
+	c.SP = 0xfd
+	c.PC = 0x0600
+	c.Bus.Write(0x0600, 0x00) // BRK
+	c.Bus.Write(0x0601, 0x00) // padding byte
+	c.Bus.Write(0xfffe, 0x00)
+	c.Bus.Write(0xffff, 0x90)
+	c.Bus.Write(0x9000, 0x40) // RTI
+	c.Flags.C = true
+
+	cycles, err := c.Step()
+	if err != nil || c.PC != 0x9000 || cycles != 7 {
+		t.Fail()
+	}
+
+	cycles, err = c.Step()
+	if err != nil || c.PC != 0x0602 || cycles != 6 || !c.Flags.C {
+		t.Fail()
+	}
+}
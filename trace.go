@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// TraceRecord captures everything about one Step call that a Tracer needs
+// to render a trace line: the instruction as fetched, its disassembly, and
+// the CPU state as it was before the instruction ran.
+type TraceRecord struct {
+	PC          uint16
+	Bytes       []uint8 // opcode followed by its operand bytes
+	Disassembly string  // e.g. "JMP $C5F5" or "LDA $0200 = 42"
+	A, X, Y, SP uint8
+	P           uint8 // packed status byte, see Flags.ToByte
+	Cycles      uint64
+}
+
+// Tracer receives one TraceRecord per instruction Step executes. A Step
+// that services a pending NMI/IRQ, or that fails on an unimplemented
+// opcode, produces no record. Cpu.Tracer is nil by default, so tracing
+// costs nothing unless a caller opts in.
+type Tracer interface {
+	OnStep(record TraceRecord)
+}
+
+// NestestTracer emits one line per instruction in the format used by the
+// canonical nestest.log, e.g.:
+//
+//	C000  4C F5 C5  JMP $C5F5                       A:00 X:00 Y:00 P:24 SP:FD CYC:  0
+//
+// which makes it possible to diff a run byte-for-byte against nestest.log,
+// the standard way to validate a 6502 core.
+type NestestTracer struct {
+	w io.Writer
+}
+
+// NewNestestTracer returns a Tracer that writes nestest-format lines to w.
+func NewNestestTracer(w io.Writer) *NestestTracer {
+	return &NestestTracer{w: w}
+}
+
+func (t *NestestTracer) OnStep(r TraceRecord) {
+	bytesCol := ""
+	for i, b := range r.Bytes {
+		if i > 0 {
+			bytesCol += " "
+		}
+		bytesCol += fmt.Sprintf("%02X", b)
+	}
+
+	fmt.Fprintf(t.w, "%04X  %-8s  %-32sA:%02X X:%02X Y:%02X P:%02X SP:%02X CYC:%3d\n",
+		r.PC, bytesCol, r.Disassembly, r.A, r.X, r.Y, r.P, r.SP, r.Cycles)
+}
+
+// traceRecord builds the TraceRecord for the instruction about to execute.
+// Must be called before PC is advanced past it.
+func (c *Cpu) traceRecord(opcode uint8, inst Instruction, op Operand) TraceRecord {
+	raw := make([]uint8, inst.Length)
+	raw[0] = opcode
+	for i := uint16(1); i < inst.Length; i++ {
+		raw[i] = c.Bus.Read(c.PC + i)
+	}
+
+	disasm := inst.Name
+	if operand := disassembleOperand(inst.Name, inst.Mode, op, raw[1:]); operand != "" {
+		disasm += " " + operand
+	}
+
+	return TraceRecord{
+		PC:          c.PC,
+		Bytes:       raw,
+		Disassembly: disasm,
+		A:           c.A,
+		X:           c.X,
+		Y:           c.Y,
+		SP:          c.SP,
+		P:           c.Flags.ToByte(),
+		Cycles:      c.Cycles,
+	}
+}
+
+// disassembleOperand renders the operand portion of a disassembled
+// instruction in nestest's style: the literal bytes as encoded, plus (for
+// modes that touch memory) the resolved effective address and the value
+// found there. raw holds the instruction's operand bytes, i.e. everything
+// after the opcode.
+func disassembleOperand(name string, mode AddressingMode, op Operand, raw []uint8) string {
+	switch mode {
+	case MODE_IMP:
+		return ""
+	case MODE_ACC:
+		return "A"
+	case MODE_IMM:
+		return fmt.Sprintf("#$%02X", raw[0])
+	case MODE_ZEP:
+		return fmt.Sprintf("$%02X = %02X", raw[0], op.Value)
+	case MODE_ZPX:
+		return fmt.Sprintf("$%02X,X @ %02X = %02X", raw[0], op.Addr, op.Value)
+	case MODE_ZPY:
+		return fmt.Sprintf("$%02X,Y @ %02X = %02X", raw[0], op.Addr, op.Value)
+	case MODE_IZX:
+		return fmt.Sprintf("($%02X,X) @ %04X = %02X", raw[0], op.Addr, op.Value)
+	case MODE_IZY:
+		return fmt.Sprintf("($%02X),Y @ %04X = %02X", raw[0], op.Addr, op.Value)
+	case MODE_ZPI:
+		return fmt.Sprintf("($%02X) = %04X = %02X", raw[0], op.Addr, op.Value)
+	case MODE_REL:
+		return fmt.Sprintf("$%04X", op.Addr)
+	case MODE_IND:
+		ptr := uint16(raw[1])<<8 | uint16(raw[0])
+		return fmt.Sprintf("($%04X) = %04X", ptr, op.Addr)
+	case MODE_ABS:
+		addr := uint16(raw[1])<<8 | uint16(raw[0])
+		if name == "JMP" || name == "JSR" {
+			return fmt.Sprintf("$%04X", addr)
+		}
+		return fmt.Sprintf("$%04X = %02X", addr, op.Value)
+	case MODE_ABX:
+		base := uint16(raw[1])<<8 | uint16(raw[0])
+		return fmt.Sprintf("$%04X,X @ %04X = %02X", base, op.Addr, op.Value)
+	case MODE_ABY:
+		base := uint16(raw[1])<<8 | uint16(raw[0])
+		return fmt.Sprintf("$%04X,Y @ %04X = %02X", base, op.Addr, op.Value)
+	}
+
+	return ""
+}
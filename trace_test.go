@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNestestTracerJmp(t *testing.T) {
+	c := newTestCpu()
+	c.PC = 0xc000
+	c.SP = 0xfd
+	c.Flags = FlagsFromByte(0x24)
+	c.Bus.Write(0xc000, 0x4c) // JMP $c5f5
+	c.Bus.Write(0xc001, 0xf5)
+	c.Bus.Write(0xc002, 0xc5)
+
+	var out bytes.Buffer
+	c.Tracer = NewNestestTracer(&out)
+	c.Step()
+
+	want := "C000  4C F5 C5  JMP $C5F5                       A:00 X:00 Y:00 P:24 SP:FD CYC:  0\n"
+	if out.String() != want {
+		t.Fatalf("got  %q\nwant %q", out.String(), want)
+	}
+}
+
+func TestDisassembleOperandMemoryModes(t *testing.T) {
+	c := newTestCpu()
+	c.Bus.Write(0x00, 0x10) // operand byte: zero-page address $10
+	c.Bus.Write(0x10, 0x42) // value stored there
+
+	c.PC = 0xffff
+	op := c.getOperand(MODE_ZEP)
+	if got := disassembleOperand("LDA", MODE_ZEP, op, []uint8{0x10}); got != "$10 = 42" {
+		t.Fatalf("got %q", got)
+	}
+
+	if got := disassembleOperand("LDA", MODE_IMM, Operand{}, []uint8{0x10}); got != "#$10" {
+		t.Fatalf("got %q", got)
+	}
+}
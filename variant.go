@@ -0,0 +1,167 @@
+package main
+
+import "fmt"
+
+// Variant captures the behavioral differences between the members of the
+// 6502 family this package emulates: the original NMOS 6502, the Ricoh
+// 2A03 (the NES's CPU, an NMOS 6502 with decimal mode disabled), and the
+// CMOS 65C02 (which fixes several NMOS bugs and adds new opcodes).
+type Variant struct {
+	Name string
+
+	// DecimalModeSupported is false on the Ricoh 2A03, where ADC/SBC
+	// ignore the D flag entirely.
+	DecimalModeSupported bool
+
+	// JMPIndirectBug reproduces the NMOS JMP ($xxFF) bug, where the high
+	// byte is fetched from $xx00 instead of crossing the page boundary.
+	// Fixed on the 65C02.
+	JMPIndirectBug bool
+
+	// BRKClearsDecimal is true on the 65C02, which clears the D flag on
+	// interrupt entry; NMOS parts leave D untouched.
+	BRKClearsDecimal bool
+
+	// Instructions maps opcodes to their decoding/execution info.
+	Instructions map[uint8]Instruction
+}
+
+// cloneInstructions returns a shallow copy of base so a variant can add or
+// override opcodes without mutating the table it was derived from.
+func cloneInstructions(base map[uint8]Instruction) map[uint8]Instruction {
+	clone := make(map[uint8]Instruction, len(base))
+	for opcode, inst := range base {
+		clone[opcode] = inst
+	}
+	return clone
+}
+
+// cmosInstructions is the NMOS table plus the 65C02-only opcodes.
+var cmosInstructions = buildCmosInstructions()
+
+func buildCmosInstructions() map[uint8]Instruction {
+	instructions := cloneInstructions(nmosInstructions)
+
+	instructions[0x80] = Instruction{"BRA", MODE_REL, 2, 2, ExecuteBra}
+
+	// immediate BIT is 65C02-only and, having no memory operand, only
+	// affects Z (see ExecuteBit).
+	instructions[0x89] = Instruction{"BIT", MODE_IMM, 2, 2, ExecuteBit}
+	instructions[0x34] = Instruction{"BIT", MODE_ZPX, 2, 4, ExecuteBit}
+	instructions[0x3c] = Instruction{"BIT", MODE_ABX, 3, 4, ExecuteBit}
+
+	instructions[0x1a] = Instruction{"INC", MODE_ACC, 1, 2, ExecuteIncA}
+	instructions[0x3a] = Instruction{"DEC", MODE_ACC, 1, 2, ExecuteDecA}
+
+	instructions[0xda] = Instruction{"PHX", MODE_IMP, 1, 3, ExecutePhx}
+	instructions[0x5a] = Instruction{"PHY", MODE_IMP, 1, 3, ExecutePhy}
+	instructions[0xfa] = Instruction{"PLX", MODE_IMP, 1, 4, ExecutePlx}
+	instructions[0x7a] = Instruction{"PLY", MODE_IMP, 1, 4, ExecutePly}
+
+	instructions[0x64] = Instruction{"STZ", MODE_ZEP, 2, 3, ExecuteStz}
+	instructions[0x74] = Instruction{"STZ", MODE_ZPX, 2, 4, ExecuteStz}
+	instructions[0x9c] = Instruction{"STZ", MODE_ABS, 3, 4, ExecuteStz}
+	instructions[0x9e] = Instruction{"STZ", MODE_ABX, 3, 5, ExecuteStz}
+
+	instructions[0x14] = Instruction{"TRB", MODE_ZEP, 2, 5, ExecuteTrb}
+	instructions[0x1c] = Instruction{"TRB", MODE_ABS, 3, 6, ExecuteTrb}
+
+	instructions[0x04] = Instruction{"TSB", MODE_ZEP, 2, 5, ExecuteTsb}
+	instructions[0x0c] = Instruction{"TSB", MODE_ABS, 3, 6, ExecuteTsb}
+
+	// zero-page-indirect addressing, added to several existing opcodes
+	instructions[0x12] = Instruction{"ORA", MODE_ZPI, 2, 5, ExecuteOra}
+	instructions[0x72] = Instruction{"ADC", MODE_ZPI, 2, 5, ExecuteAdc}
+	instructions[0x32] = Instruction{"AND", MODE_ZPI, 2, 5, ExecuteAnd}
+	instructions[0xd2] = Instruction{"CMP", MODE_ZPI, 2, 5, ExecuteCmp}
+	instructions[0x52] = Instruction{"EOR", MODE_ZPI, 2, 5, ExecuteEor}
+	instructions[0xb2] = Instruction{"LDA", MODE_ZPI, 2, 5, ExecuteLda}
+	instructions[0xf2] = Instruction{"SBC", MODE_ZPI, 2, 5, ExecuteSbc}
+	instructions[0x92] = Instruction{"STA", MODE_ZPI, 2, 5, ExecuteSta}
+
+	return instructions
+}
+
+// NMOSVariant is the original 6502 as used outside the NES: decimal mode,
+// the JMP indirect page-boundary bug, and no 65C02 extensions.
+var NMOSVariant = &Variant{
+	Name:                 "NMOS 6502",
+	DecimalModeSupported: true,
+	JMPIndirectBug:       true,
+	BRKClearsDecimal:     false,
+	Instructions:         nmosInstructions,
+}
+
+// Ricoh2A03Variant is the NES's CPU: an NMOS 6502 with the decimal mode
+// circuitry removed (ADC/SBC always behave as if D were clear).
+var Ricoh2A03Variant = &Variant{
+	Name:                 "Ricoh 2A03",
+	DecimalModeSupported: false,
+	JMPIndirectBug:       true,
+	BRKClearsDecimal:     false,
+	Instructions:         nmosInstructions,
+}
+
+// CMOS65C02Variant fixes the NMOS JMP indirect bug, clears D on interrupt
+// entry, and adds the 65C02 opcode extensions.
+var CMOS65C02Variant = &Variant{
+	Name:                 "CMOS 65C02",
+	DecimalModeSupported: true,
+	JMPIndirectBug:       false,
+	BRKClearsDecimal:     true,
+	Instructions:         cmosInstructions,
+}
+
+// NewNMOS6502 returns a Cpu emulating the original NMOS 6502.
+func NewNMOS6502(bus Bus) *Cpu {
+	return &Cpu{Bus: bus, Variant: NMOSVariant}
+}
+
+// NewRicoh2A03 returns a Cpu emulating the NES's Ricoh 2A03.
+func NewRicoh2A03(bus Bus) *Cpu {
+	return &Cpu{Bus: bus, Variant: Ricoh2A03Variant}
+}
+
+// NewCMOS65C02 returns a Cpu emulating the CMOS 65C02.
+func NewCMOS65C02(bus Bus) *Cpu {
+	return &Cpu{Bus: bus, Variant: CMOS65C02Variant}
+}
+
+// Step decodes and executes the instruction at PC, returning the number of
+// cycles it took. An error is returned (and no state changed) if the
+// opcode isn't implemented by the Cpu's variant.
+func (c *Cpu) Step() (cycles int, err error) {
+	if c.NMIPending {
+		c.NMI()
+		c.Cycles += 7
+		return 7, nil
+	}
+	if c.IRQPending && !c.Flags.I {
+		c.IRQ()
+		c.Cycles += 7
+		return 7, nil
+	}
+
+	opcode := c.Bus.Read(c.PC)
+	inst, ok := c.Variant.Instructions[opcode]
+	if !ok {
+		return 0, fmt.Errorf("cpu: unimplemented opcode 0x%02x at $%04x", opcode, c.PC)
+	}
+
+	op := c.getOperand(inst.Mode)
+
+	if c.Tracer != nil {
+		c.Tracer.OnStep(c.traceRecord(opcode, inst, op))
+	}
+
+	c.PC = c.PC + inst.Length
+	extra := c.Execute(inst, op)
+
+	total := inst.Cycles + extra
+	if op.PageCrossed && pageCrossReadOps[inst.Name] {
+		total++
+	}
+	c.Cycles = c.Cycles + uint64(total)
+
+	return total, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestVariantConstructors(t *testing.T) {
+	bus := NewMemoryBus()
+
+	nmos := NewNMOS6502(bus)
+	if nmos.Variant.JMPIndirectBug != true || nmos.Variant.DecimalModeSupported != true {
+		t.Fail()
+	}
+
+	ricoh := NewRicoh2A03(bus)
+	if ricoh.Variant.DecimalModeSupported != false {
+		t.Fail()
+	}
+
+	cmos := NewCMOS65C02(bus)
+	if cmos.Variant.JMPIndirectBug != false || cmos.Variant.BRKClearsDecimal != true {
+		t.Fail()
+	}
+}
+
+func TestCmosOnlyOpcodesNotOnNmos(t *testing.T) {
+	if _, ok := nmosInstructions[0x80]; ok {
+		t.Fail() // BRA is 65C02 only
+	}
+	if _, ok := cmosInstructions[0x80]; !ok {
+		t.Fail()
+	}
+}
+
+func TestZeroPageIndirectOpcodes(t *testing.T) {
+	// ($zp) addressing is a 65C02 extension, added to ORA plus these.
+	for opcode, name := range map[uint8]string{
+		0x12: "ORA", 0x72: "ADC", 0x32: "AND", 0xd2: "CMP",
+		0x52: "EOR", 0xb2: "LDA", 0xf2: "SBC", 0x92: "STA",
+	} {
+		if _, ok := nmosInstructions[opcode]; ok {
+			t.Fail()
+		}
+		inst, ok := cmosInstructions[opcode]
+		if !ok || inst.Name != name || inst.Mode != MODE_ZPI {
+			t.Fail()
+		}
+	}
+}
+
+func TestBitOpcodes(t *testing.T) {
+	// BIT zp/abs are NMOS; the immediate and indexed forms are 65C02-only.
+	if _, ok := nmosInstructions[0x24]; !ok {
+		t.Fail()
+	}
+	if _, ok := nmosInstructions[0x2c]; !ok {
+		t.Fail()
+	}
+	for _, opcode := range []uint8{0x89, 0x34, 0x3c} {
+		if _, ok := nmosInstructions[opcode]; ok {
+			t.Fail()
+		}
+		if _, ok := cmosInstructions[opcode]; !ok {
+			t.Fail()
+		}
+	}
+}
+
+func TestExecuteIncADecA(t *testing.T) {
+	c := Cpu{}
+
+	c.A = 0x7f
+	ExecuteIncA(&c, Operand{})
+	if c.A != 0x80 || !c.Flags.N {
+		t.Fail()
+	}
+
+	c.A = 0x01
+	ExecuteDecA(&c, Operand{})
+	if c.A != 0x00 || !c.Flags.Z {
+		t.Fail()
+	}
+}
+
+func TestExecutePhxPlx(t *testing.T) {
+	c := Cpu{Bus: NewMemoryBus(), SP: 0xfd}
+	c.Bus.Attach(NewRam(), "RAM", 0x0000, 0x1fff)
+
+	c.X = 0x42
+	ExecutePhx(&c, Operand{})
+	c.X = 0x00
+	ExecutePlx(&c, Operand{})
+	if c.X != 0x42 {
+		t.Fail()
+	}
+}